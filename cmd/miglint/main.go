@@ -3,39 +3,156 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/tetzng/miglint/internal/config"
+	"github.com/tetzng/miglint/internal/format"
 	"github.com/tetzng/miglint/internal/lint"
 )
 
 func main() {
-	cfg, code := parseFlags()
+	cli, configPath, formatName, code := parseFlags()
 	if code != 0 {
 		os.Exit(code)
 	}
 
-	lintErrors, err := lint.Lint(*cfg)
+	file, code := loadConfigFile(configPath, cli.Path)
+	if code != 0 {
+		os.Exit(code)
+	}
+
+	explicit := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	configs, err := config.Resolve(file, *cli, explicit)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, err.Error())
-		os.Exit(1)
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(2)
+	}
+
+	out := io.Writer(os.Stderr)
+	isText := formatName == "" || formatName == format.Text
+	if !isText {
+		out = os.Stdout
 	}
 
-	if len(lintErrors) > 0 {
-		for _, e := range lintErrors {
-			fmt.Fprintln(os.Stderr, e)
+	var results []format.ProfileResult
+	var anyErrors bool
+	for _, named := range configs {
+		if err := validate(named.Config); err != nil {
+			flushResults(out, formatName, results)
+			fmt.Fprintln(os.Stderr, prefixed(named.Name, err.Error()))
+			os.Exit(2)
 		}
+
+		findings, err := lint.Lint(named.Config)
+		if err != nil {
+			flushResults(out, formatName, results)
+			fmt.Fprintln(os.Stderr, prefixed(named.Name, err.Error()))
+			os.Exit(1)
+		}
+		if len(findings) > 0 {
+			anyErrors = true
+		}
+		results = append(results, format.ProfileResult{Name: named.Name, BasePath: named.Config.Path, Findings: findings})
+	}
+
+	if err := format.Write(out, formatName, results); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(2)
+	}
+
+	if anyErrors {
 		os.Exit(1)
 	}
 
-	fmt.Println("migration lint passed")
+	if isText {
+		fmt.Println("migration lint passed")
+	}
+}
+
+// flushResults renders the findings already gathered from earlier profiles
+// before main exits on a later profile's fatal (validate/Lint) error, so
+// those findings aren't silently dropped.
+func flushResults(out io.Writer, formatName string, results []format.ProfileResult) {
+	if len(results) == 0 {
+		return
+	}
+	if err := format.Write(out, formatName, results); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+	}
+}
+
+// prefixed prepends "profile: " to msg when profile is non-empty, so errors
+// from a multi-profile config file are traceable to their source.
+func prefixed(profile, msg string) string {
+	if profile == "" {
+		return msg
+	}
+	return fmt.Sprintf("%s: %s", profile, msg)
+}
+
+func loadConfigFile(configPath, cfgPath string) (*config.File, int) {
+	if configPath == "" {
+		startDir := cfgPath
+		if startDir == "" {
+			wd, err := os.Getwd()
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "error: failed to determine working directory:", err)
+				return nil, 1
+			}
+			startDir = wd
+		}
+		found, ok := config.Discover(startDir)
+		if !ok {
+			return nil, 0
+		}
+		configPath = found
+	}
+
+	file, err := config.Load(configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return nil, 2
+	}
+	return file, 0
 }
 
-func parseFlags() (*lint.Config, int) {
+func validate(cfg lint.Config) error {
+	if cfg.Path == "" {
+		return fmt.Errorf("-path is required (set via flag or config file)")
+	}
+	if cfg.EnforceExt && cfg.Ext == "" {
+		return fmt.Errorf("-enforce-ext requires -ext")
+	}
+	if cfg.Digits < 0 {
+		return fmt.Errorf("-digits must be >= 0")
+	}
+	switch lint.Convention(cfg.Convention) {
+	case lint.ConventionDefault, lint.ConventionGoose, lint.ConventionFlyway, lint.ConventionDbmate, lint.ConventionSqlx, lint.ConventionCustom:
+	default:
+		return fmt.Errorf("-convention %q is not one of goose, flyway, dbmate, sqlx, custom", cfg.Convention)
+	}
+	if cfg.Convention == "custom" && cfg.CustomPattern == "" {
+		return fmt.Errorf("-convention=custom requires -custom-pattern")
+	}
+	if !cfg.LintSQL && (cfg.RequireTx || cfg.Dialect != "") {
+		return fmt.Errorf("-require-tx/-dialect require -lint-sql")
+	}
+	return nil
+}
+
+func parseFlags() (*lint.Config, string, string, int) {
 	cfg := &lint.Config{}
+	var configPath, formatName string
 
-	flag.StringVar(&cfg.Path, "path", "", "directory containing migration files (required)")
+	flag.StringVar(&configPath, "config", "", "path to a miglint config file (default: auto-discover .miglint.toml from -path upward)")
+	flag.StringVar(&formatName, "format", format.Text, "output format: text, json, sarif, or github")
+	flag.StringVar(&cfg.Path, "path", "", "directory containing migration files (required unless set via config file)")
 	flag.StringVar(&cfg.Ext, "ext", "", "extension filter; match final ext (sql) or full ext part (sql.gz)")
 	flag.BoolVar(&cfg.EnforceExt, "enforce-ext", false, "with -ext, error when migration-like files (incl. .up/.down) don’t match the ext")
 	flag.BoolVar(&cfg.NoGaps, "no-gaps", false, "require contiguous version sequence (no gaps)")
@@ -43,6 +160,16 @@ func parseFlags() (*lint.Config, int) {
 	flag.BoolVar(&cfg.RequireDown, "require-down", false, "require both up and down for every version")
 	flag.BoolVar(&cfg.StrictNameMatch, "strict-name-match", false, "require up/down to have identical NAME and ExtPart for the same version")
 	flag.BoolVar(&cfg.StrictPattern, "strict-pattern", false, "treat candidate but unmatched files as errors")
+	flag.BoolVar(&cfg.Recursive, "recursive", false, "descend into subdirectories of -path")
+	flag.StringVar(&cfg.Convention, "convention", "", "naming convention: goose, flyway, dbmate, sqlx, custom (default: <VERSION>_<NAME>.(up|down).<ext>)")
+	flag.StringVar(&cfg.CustomPattern, "custom-pattern", "", "with -convention=custom, a regex with named groups version/name/direction/ext")
+	flag.BoolVar(&cfg.LintSQL, "lint-sql", false, "analyze .sql migration bodies for reversibility and destructive statements")
+	flag.BoolVar(&cfg.RequireTx, "require-tx", false, "with -lint-sql, require up migrations to wrap statements in BEGIN/COMMIT")
+	flag.StringVar(&cfg.Dialect, "dialect", "", "with -lint-sql, enable dialect-specific advice (postgres)")
+	var includes, excludes, excludeVersions multiFlag
+	flag.Var(&includes, "include", "glob (relative to -path) to allow; repeatable, allow-list semantics")
+	flag.Var(&excludes, "exclude", "glob (relative to -path) to skip; repeatable, prunes matching directories")
+	flag.Var(&excludeVersions, "exclude-version", "VERSION to ignore entirely; repeatable")
 
 	flag.Usage = func() {
 		if _, err := fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s -path DIR [options]\n", filepath.Base(os.Args[0])); err != nil {
@@ -57,21 +184,38 @@ func parseFlags() (*lint.Config, int) {
 		cfg.Ext = strings.TrimPrefix(cfg.Ext, ".")
 	}
 
-	if cfg.Path == "" {
-		fmt.Fprintln(os.Stderr, "error: -path is required")
+	if !format.Valid(formatName) {
+		fmt.Fprintf(os.Stderr, "error: -format %q is not one of text, json, sarif, github\n", formatName)
 		flag.Usage()
-		return nil, 2
+		return nil, "", "", 2
 	}
-	if cfg.EnforceExt && cfg.Ext == "" {
-		fmt.Fprintln(os.Stderr, "error: -enforce-ext requires -ext")
-		flag.Usage()
-		return nil, 2
+
+	cfg.IncludePatterns = []string(includes)
+	cfg.ExcludePatterns = []string(excludes)
+	for _, v := range excludeVersions {
+		version, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: -exclude-version %q is not an integer\n", v)
+			flag.Usage()
+			return nil, "", "", 2
+		}
+		cfg.ExcludeVersions = append(cfg.ExcludeVersions, version)
 	}
-	if cfg.Digits < 0 {
-		fmt.Fprintln(os.Stderr, "error: -digits must be >= 0")
-		flag.Usage()
-		return nil, 2
+
+	return cfg, configPath, formatName, 0
+}
+
+// multiFlag collects repeatable string flags (e.g. -include a -include b).
+type multiFlag []string
+
+func (m *multiFlag) String() string {
+	if m == nil {
+		return ""
 	}
+	return strings.Join(*m, ",")
+}
 
-	return cfg, 0
+func (m *multiFlag) Set(value string) error {
+	*m = append(*m, value)
+	return nil
 }
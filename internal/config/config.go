@@ -0,0 +1,469 @@
+// Package config loads miglint's optional TOML config file and merges it
+// with CLI-provided flags using an "explicit overrides zero" strategy: a
+// flag only clobbers the file's value when it was actually set on the
+// command line, and slice fields (include/exclude patterns, excluded
+// versions) are concatenated rather than replaced.
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/tetzng/miglint/internal/lint"
+)
+
+// FileName is the config file name auto-discovered when -config is not given.
+const FileName = ".miglint.toml"
+
+// File is the parsed contents of a miglint config file.
+type File struct {
+	Defaults Settings
+	Profiles []Profile
+}
+
+// Profile is a single named lint target declared via [[profiles]].
+type Profile struct {
+	Name     string
+	Settings Settings
+}
+
+// Settings mirrors the subset of lint.Config that can come from a config
+// file. Scalar fields are pointers so "unset" can be told apart from "set to
+// the zero value", which is what makes CLI-override merging possible.
+type Settings struct {
+	Path            *string
+	Ext             *string
+	EnforceExt      *bool
+	NoGaps          *bool
+	Digits          *int
+	RequireDown     *bool
+	StrictNameMatch *bool
+	StrictPattern   *bool
+	Recursive       *bool
+	Convention      *string
+	CustomPattern   *string
+	LintSQL         *bool
+	RequireTx       *bool
+	Dialect         *string
+	IncludePatterns []string
+	ExcludePatterns []string
+	ExcludePaths    []string
+	ExcludeVersions []int64
+}
+
+// NamedConfig pairs a resolved lint.Config with the profile name it came
+// from (empty when no [[profiles]] were declared).
+type NamedConfig struct {
+	Name   string
+	Config lint.Config
+}
+
+// Discover walks upward from startDir looking for FileName, returning its
+// path and true if found. It stops at the filesystem root.
+func Discover(startDir string) (string, bool) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		candidate := filepath.Join(dir, FileName)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// Load reads and parses the config file at path.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %v", path, err)
+	}
+
+	f := &File{}
+	var current *Settings = &f.Defaults
+
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if line == "[[profiles]]" {
+			f.Profiles = append(f.Profiles, Profile{})
+			current = &f.Profiles[len(f.Profiles)-1].Settings
+			continue
+		}
+
+		key, rawValue, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected key = value, got %q", path, lineNo, line)
+		}
+		key = strings.TrimSpace(key)
+		rawValue = strings.TrimSpace(rawValue)
+
+		if key == "name" && current != &f.Defaults {
+			name, err := parseString(rawValue)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %v", path, lineNo, err)
+			}
+			f.Profiles[len(f.Profiles)-1].Name = name
+			continue
+		}
+
+		if err := assign(current, key, rawValue); err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", path, lineNo, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %v", path, err)
+	}
+
+	return f, nil
+}
+
+func assign(s *Settings, key, rawValue string) error {
+	switch key {
+	case "path":
+		v, err := parseString(rawValue)
+		if err != nil {
+			return err
+		}
+		s.Path = &v
+	case "ext":
+		v, err := parseString(rawValue)
+		if err != nil {
+			return err
+		}
+		v = strings.TrimPrefix(v, ".")
+		s.Ext = &v
+	case "enforce_ext":
+		v, err := parseBool(rawValue)
+		if err != nil {
+			return err
+		}
+		s.EnforceExt = &v
+	case "no_gaps":
+		v, err := parseBool(rawValue)
+		if err != nil {
+			return err
+		}
+		s.NoGaps = &v
+	case "digits":
+		v, err := parseInt(rawValue)
+		if err != nil {
+			return err
+		}
+		s.Digits = &v
+	case "require_down":
+		v, err := parseBool(rawValue)
+		if err != nil {
+			return err
+		}
+		s.RequireDown = &v
+	case "strict_name_match":
+		v, err := parseBool(rawValue)
+		if err != nil {
+			return err
+		}
+		s.StrictNameMatch = &v
+	case "strict_pattern":
+		v, err := parseBool(rawValue)
+		if err != nil {
+			return err
+		}
+		s.StrictPattern = &v
+	case "recursive":
+		v, err := parseBool(rawValue)
+		if err != nil {
+			return err
+		}
+		s.Recursive = &v
+	case "convention":
+		v, err := parseString(rawValue)
+		if err != nil {
+			return err
+		}
+		s.Convention = &v
+	case "custom_pattern":
+		v, err := parseString(rawValue)
+		if err != nil {
+			return err
+		}
+		s.CustomPattern = &v
+	case "lint_sql":
+		v, err := parseBool(rawValue)
+		if err != nil {
+			return err
+		}
+		s.LintSQL = &v
+	case "require_tx":
+		v, err := parseBool(rawValue)
+		if err != nil {
+			return err
+		}
+		s.RequireTx = &v
+	case "dialect":
+		v, err := parseString(rawValue)
+		if err != nil {
+			return err
+		}
+		s.Dialect = &v
+	case "include_patterns":
+		v, err := parseStringArray(rawValue)
+		if err != nil {
+			return err
+		}
+		s.IncludePatterns = append(s.IncludePatterns, v...)
+	case "exclude_patterns":
+		v, err := parseStringArray(rawValue)
+		if err != nil {
+			return err
+		}
+		s.ExcludePatterns = append(s.ExcludePatterns, v...)
+	case "exclude_paths":
+		v, err := parseStringArray(rawValue)
+		if err != nil {
+			return err
+		}
+		s.ExcludePaths = append(s.ExcludePaths, v...)
+	case "exclude_versions":
+		v, err := parseIntArray(rawValue)
+		if err != nil {
+			return err
+		}
+		s.ExcludeVersions = append(s.ExcludeVersions, v...)
+	default:
+		return fmt.Errorf("unknown config key %q", key)
+	}
+	return nil
+}
+
+func parseString(raw string) (string, error) {
+	if len(raw) >= 2 && strings.HasPrefix(raw, `"`) && strings.HasSuffix(raw, `"`) {
+		return raw[1 : len(raw)-1], nil
+	}
+	return "", fmt.Errorf("expected a quoted string, got %q", raw)
+}
+
+func parseBool(raw string) (bool, error) {
+	switch raw {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return false, fmt.Errorf("expected true or false, got %q", raw)
+	}
+}
+
+func parseInt(raw string) (int, error) {
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("expected an integer, got %q", raw)
+	}
+	return v, nil
+}
+
+func parseStringArray(raw string) ([]string, error) {
+	inner, err := arrayInner(raw)
+	if err != nil {
+		return nil, err
+	}
+	if inner == "" {
+		return nil, nil
+	}
+
+	var out []string
+	for _, part := range strings.Split(inner, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		v, err := parseString(part)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func parseIntArray(raw string) ([]int64, error) {
+	inner, err := arrayInner(raw)
+	if err != nil {
+		return nil, err
+	}
+	if inner == "" {
+		return nil, nil
+	}
+
+	var out []int64
+	for _, part := range strings.Split(inner, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		v, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected an integer array element, got %q", part)
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func arrayInner(raw string) (string, error) {
+	if !strings.HasPrefix(raw, "[") || !strings.HasSuffix(raw, "]") {
+		return "", fmt.Errorf("expected an array, got %q", raw)
+	}
+	return strings.TrimSpace(raw[1 : len(raw)-1]), nil
+}
+
+// Resolve combines an optional parsed file with CLI flags into the ordered
+// list of lint.Config runs to perform. explicitFlags holds the flag.Flag
+// names actually set on the command line (see flag.Visit); only those
+// override file-provided scalar values, per the package doc's merge rule.
+//
+// When the file declares no [[profiles]], a single unnamed run is produced
+// from the file's top-level defaults (or CLI alone, if file is nil). A
+// config file that does declare [[profiles]] gives each profile its own
+// path, so an explicit -path flag (which would otherwise clobber every
+// profile with the same directory) is rejected instead.
+func Resolve(file *File, cli lint.Config, explicitFlags map[string]bool) ([]NamedConfig, error) {
+	if file == nil || len(file.Profiles) == 0 {
+		cfg := lint.Config{}
+		if file != nil {
+			applySettings(&cfg, file.Defaults)
+		}
+		applyCLIOverrides(&cfg, cli, explicitFlags)
+		return []NamedConfig{{Config: cfg}}, nil
+	}
+
+	if explicitFlags["path"] {
+		return nil, fmt.Errorf("-path cannot be combined with a config file that declares [[profiles]]; set each profile's own path instead")
+	}
+
+	out := make([]NamedConfig, 0, len(file.Profiles))
+	for _, p := range file.Profiles {
+		cfg := lint.Config{}
+		applySettings(&cfg, file.Defaults)
+		applySettings(&cfg, p.Settings)
+		applyCLIOverrides(&cfg, cli, explicitFlags)
+		out = append(out, NamedConfig{Name: p.Name, Config: cfg})
+	}
+	return out, nil
+}
+
+func applySettings(cfg *lint.Config, s Settings) {
+	if s.Path != nil {
+		cfg.Path = *s.Path
+	}
+	if s.Ext != nil {
+		cfg.Ext = *s.Ext
+	}
+	if s.EnforceExt != nil {
+		cfg.EnforceExt = *s.EnforceExt
+	}
+	if s.NoGaps != nil {
+		cfg.NoGaps = *s.NoGaps
+	}
+	if s.Digits != nil {
+		cfg.Digits = *s.Digits
+		cfg.DigitsExplicit = true
+	}
+	if s.RequireDown != nil {
+		cfg.RequireDown = *s.RequireDown
+	}
+	if s.StrictNameMatch != nil {
+		cfg.StrictNameMatch = *s.StrictNameMatch
+	}
+	if s.StrictPattern != nil {
+		cfg.StrictPattern = *s.StrictPattern
+	}
+	if s.Recursive != nil {
+		cfg.Recursive = *s.Recursive
+	}
+	if s.Convention != nil {
+		cfg.Convention = *s.Convention
+	}
+	if s.CustomPattern != nil {
+		cfg.CustomPattern = *s.CustomPattern
+	}
+	if s.LintSQL != nil {
+		cfg.LintSQL = *s.LintSQL
+	}
+	if s.RequireTx != nil {
+		cfg.RequireTx = *s.RequireTx
+	}
+	if s.Dialect != nil {
+		cfg.Dialect = *s.Dialect
+	}
+	cfg.IncludePatterns = append(cfg.IncludePatterns, s.IncludePatterns...)
+	cfg.ExcludePatterns = append(cfg.ExcludePatterns, s.ExcludePatterns...)
+	cfg.ExcludePaths = append(cfg.ExcludePaths, s.ExcludePaths...)
+	cfg.ExcludeVersions = append(cfg.ExcludeVersions, s.ExcludeVersions...)
+}
+
+func applyCLIOverrides(cfg *lint.Config, cli lint.Config, explicitFlags map[string]bool) {
+	if explicitFlags["path"] {
+		cfg.Path = cli.Path
+	}
+	if explicitFlags["ext"] {
+		cfg.Ext = cli.Ext
+	}
+	if explicitFlags["enforce-ext"] {
+		cfg.EnforceExt = cli.EnforceExt
+	}
+	if explicitFlags["no-gaps"] {
+		cfg.NoGaps = cli.NoGaps
+	}
+	if explicitFlags["digits"] {
+		cfg.Digits = cli.Digits
+		cfg.DigitsExplicit = true
+	}
+	if explicitFlags["require-down"] {
+		cfg.RequireDown = cli.RequireDown
+	}
+	if explicitFlags["strict-name-match"] {
+		cfg.StrictNameMatch = cli.StrictNameMatch
+	}
+	if explicitFlags["strict-pattern"] {
+		cfg.StrictPattern = cli.StrictPattern
+	}
+	if explicitFlags["recursive"] {
+		cfg.Recursive = cli.Recursive
+	}
+	if explicitFlags["convention"] {
+		cfg.Convention = cli.Convention
+	}
+	if explicitFlags["custom-pattern"] {
+		cfg.CustomPattern = cli.CustomPattern
+	}
+	if explicitFlags["lint-sql"] {
+		cfg.LintSQL = cli.LintSQL
+	}
+	if explicitFlags["require-tx"] {
+		cfg.RequireTx = cli.RequireTx
+	}
+	if explicitFlags["dialect"] {
+		cfg.Dialect = cli.Dialect
+	}
+	cfg.IncludePatterns = append(cfg.IncludePatterns, cli.IncludePatterns...)
+	cfg.ExcludePatterns = append(cfg.ExcludePatterns, cli.ExcludePatterns...)
+	cfg.ExcludeVersions = append(cfg.ExcludeVersions, cli.ExcludeVersions...)
+}
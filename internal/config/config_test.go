@@ -0,0 +1,259 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tetzng/miglint/internal/lint"
+)
+
+func TestLoadTopLevelDefaults(t *testing.T) {
+	path := writeConfig(t, `
+require_down = true
+digits = 6
+include_patterns = ["db/**"]
+`)
+
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.Defaults.RequireDown == nil || !*f.Defaults.RequireDown {
+		t.Fatalf("expected require_down to be true, got %+v", f.Defaults.RequireDown)
+	}
+	if f.Defaults.Digits == nil || *f.Defaults.Digits != 6 {
+		t.Fatalf("expected digits=6, got %+v", f.Defaults.Digits)
+	}
+	if len(f.Defaults.IncludePatterns) != 1 || f.Defaults.IncludePatterns[0] != "db/**" {
+		t.Fatalf("unexpected include_patterns: %v", f.Defaults.IncludePatterns)
+	}
+}
+
+func TestLoadNormalizesLeadingDotExt(t *testing.T) {
+	path := writeConfig(t, `
+ext = ".sql"
+`)
+
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.Defaults.Ext == nil || *f.Defaults.Ext != "sql" {
+		t.Fatalf("expected ext to be normalized to \"sql\", got %+v", f.Defaults.Ext)
+	}
+}
+
+func TestLoadConventionAndSQLKeys(t *testing.T) {
+	path := writeConfig(t, `
+convention = "flyway"
+custom_pattern = "(?P<version>\d+)_(?P<name>.+)"
+lint_sql = true
+require_tx = true
+dialect = "postgres"
+`)
+
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.Defaults.Convention == nil || *f.Defaults.Convention != "flyway" {
+		t.Fatalf("expected convention to be \"flyway\", got %+v", f.Defaults.Convention)
+	}
+	if f.Defaults.CustomPattern == nil || *f.Defaults.CustomPattern != `(?P<version>\d+)_(?P<name>.+)` {
+		t.Fatalf("unexpected custom_pattern: %+v", f.Defaults.CustomPattern)
+	}
+	if f.Defaults.LintSQL == nil || !*f.Defaults.LintSQL {
+		t.Fatalf("expected lint_sql to be true, got %+v", f.Defaults.LintSQL)
+	}
+	if f.Defaults.RequireTx == nil || !*f.Defaults.RequireTx {
+		t.Fatalf("expected require_tx to be true, got %+v", f.Defaults.RequireTx)
+	}
+	if f.Defaults.Dialect == nil || *f.Defaults.Dialect != "postgres" {
+		t.Fatalf("expected dialect to be \"postgres\", got %+v", f.Defaults.Dialect)
+	}
+}
+
+func TestResolveConventionAndSQLKeys(t *testing.T) {
+	f := &File{Defaults: Settings{
+		Convention:    strPtr("flyway"),
+		CustomPattern: strPtr("(?P<version>\\d+)_(?P<name>.+)"),
+		LintSQL:       boolPtr(true),
+		RequireTx:     boolPtr(true),
+		Dialect:       strPtr("postgres"),
+	}}
+
+	configs, err := Resolve(f, lint.Config{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg := configs[0].Config
+	if cfg.Convention != "flyway" {
+		t.Fatalf("expected convention to be \"flyway\", got %q", cfg.Convention)
+	}
+	if cfg.CustomPattern != `(?P<version>\d+)_(?P<name>.+)` {
+		t.Fatalf("unexpected custom pattern: %q", cfg.CustomPattern)
+	}
+	if !cfg.LintSQL {
+		t.Fatalf("expected lint_sql to resolve to true")
+	}
+	if !cfg.RequireTx {
+		t.Fatalf("expected require_tx to resolve to true")
+	}
+	if cfg.Dialect != "postgres" {
+		t.Fatalf("expected dialect to be \"postgres\", got %q", cfg.Dialect)
+	}
+}
+
+func TestLoadProfiles(t *testing.T) {
+	path := writeConfig(t, `
+require_down = true
+
+[[profiles]]
+name = "service_a"
+path = "service_a/db/migrations"
+
+[[profiles]]
+name = "service_b"
+path = "service_b/schema"
+require_down = false
+`)
+
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(f.Profiles) != 2 {
+		t.Fatalf("expected 2 profiles, got %d", len(f.Profiles))
+	}
+	if f.Profiles[0].Name != "service_a" || *f.Profiles[0].Settings.Path != "service_a/db/migrations" {
+		t.Fatalf("unexpected profile 0: %+v", f.Profiles[0])
+	}
+	if f.Profiles[1].Settings.RequireDown == nil || *f.Profiles[1].Settings.RequireDown {
+		t.Fatalf("expected service_b to override require_down to false: %+v", f.Profiles[1])
+	}
+}
+
+func TestResolveFileDefaultsWithoutProfiles(t *testing.T) {
+	f := &File{Defaults: Settings{RequireDown: boolPtr(true)}}
+
+	configs, err := Resolve(f, lint.Config{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(configs) != 1 {
+		t.Fatalf("expected 1 config, got %d", len(configs))
+	}
+	if !configs[0].Config.RequireDown {
+		t.Fatalf("expected RequireDown inherited from file defaults")
+	}
+}
+
+func TestResolveCLIOverridesOnlyExplicitFlags(t *testing.T) {
+	f := &File{Defaults: Settings{RequireDown: boolPtr(true), Digits: intPtr(6)}}
+	cli := lint.Config{Digits: 4}
+	explicit := map[string]bool{"digits": true}
+
+	configs, err := Resolve(f, cli, explicit)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg := configs[0].Config
+	if !cfg.RequireDown {
+		t.Fatalf("expected unset CLI flag to inherit file value for RequireDown")
+	}
+	if cfg.Digits != 4 {
+		t.Fatalf("expected explicit CLI flag to override file value, got digits=%d", cfg.Digits)
+	}
+}
+
+func TestResolveConcatenatesSliceFields(t *testing.T) {
+	f := &File{Defaults: Settings{ExcludePatterns: []string{"vendor/**"}}}
+	cli := lint.Config{ExcludePatterns: []string{"testdata/**"}}
+
+	configs, err := Resolve(f, cli, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg := configs[0].Config
+	if len(cfg.ExcludePatterns) != 2 {
+		t.Fatalf("expected file and CLI exclude patterns to be concatenated, got %v", cfg.ExcludePatterns)
+	}
+}
+
+func TestResolvePerProfile(t *testing.T) {
+	f := &File{
+		Defaults: Settings{RequireDown: boolPtr(true)},
+		Profiles: []Profile{
+			{Name: "service_a", Settings: Settings{Path: strPtr("service_a/db/migrations")}},
+			{Name: "service_b", Settings: Settings{Path: strPtr("service_b/schema"), RequireDown: boolPtr(false)}},
+		},
+	}
+
+	configs, err := Resolve(f, lint.Config{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(configs) != 2 {
+		t.Fatalf("expected 2 configs, got %d", len(configs))
+	}
+	if configs[0].Name != "service_a" || !configs[0].Config.RequireDown {
+		t.Fatalf("unexpected service_a config: %+v", configs[0])
+	}
+	if configs[1].Name != "service_b" || configs[1].Config.RequireDown {
+		t.Fatalf("unexpected service_b config: %+v", configs[1])
+	}
+}
+
+func TestResolveRejectsPathFlagWithProfiles(t *testing.T) {
+	f := &File{
+		Profiles: []Profile{
+			{Name: "service_a", Settings: Settings{Path: strPtr("service_a/db/migrations")}},
+			{Name: "service_b", Settings: Settings{Path: strPtr("service_b/schema")}},
+		},
+	}
+	cli := lint.Config{Path: "service_a/db/migrations"}
+	explicit := map[string]bool{"path": true}
+
+	_, err := Resolve(f, cli, explicit)
+	if err == nil {
+		t.Fatalf("expected an error when -path is combined with [[profiles]]")
+	}
+	if !strings.Contains(err.Error(), "-path") {
+		t.Fatalf("expected the error to mention -path, got %v", err)
+	}
+}
+
+func TestDiscoverWalksUpward(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, FileName), []byte(""), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	path, ok := Discover(nested)
+	if !ok {
+		t.Fatalf("expected to discover config walking up from %s", nested)
+	}
+	if filepath.Dir(path) != root {
+		t.Fatalf("expected discovered config in %s, got %s", root, path)
+	}
+}
+
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return path
+}
+
+func boolPtr(b bool) *bool    { return &b }
+func intPtr(i int) *int       { return &i }
+func strPtr(s string) *string { return &s }
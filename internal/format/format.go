@@ -0,0 +1,267 @@
+// Package format renders lint.Finding results for human and machine
+// consumers, keyed off the CLI's -format flag.
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/tetzng/miglint/internal/lint"
+)
+
+// ProfileResult is one config profile's findings, ready to render. Name is
+// empty for the single run made when no [[profiles]] are declared; BasePath is the
+// profile's lint.Config.Path, used to relativize file locations.
+type ProfileResult struct {
+	Name     string
+	BasePath string
+	Findings []lint.Finding
+}
+
+const (
+	Text   = "text"
+	JSON   = "json"
+	SARIF  = "sarif"
+	GitHub = "github"
+)
+
+// Valid reports whether name is a supported -format value ("" means Text).
+func Valid(name string) bool {
+	switch name {
+	case "", Text, JSON, SARIF, GitHub:
+		return true
+	default:
+		return false
+	}
+}
+
+// Write renders results in the given format to w.
+func Write(w io.Writer, name string, results []ProfileResult) error {
+	switch name {
+	case "", Text:
+		return writeText(w, results)
+	case JSON:
+		return writeJSON(w, results)
+	case SARIF:
+		return writeSARIF(w, results)
+	case GitHub:
+		return writeGitHub(w, results)
+	default:
+		return fmt.Errorf("unknown format: %s", name)
+	}
+}
+
+func prefixed(profile, msg string) string {
+	if profile == "" {
+		return msg
+	}
+	return fmt.Sprintf("%s: %s", profile, msg)
+}
+
+func writeText(w io.Writer, results []ProfileResult) error {
+	for _, r := range results {
+		for _, f := range r.Findings {
+			if _, err := fmt.Fprintln(w, prefixed(r.Name, f.Message)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeGitHub(w io.Writer, results []ProfileResult) error {
+	for _, r := range results {
+		for _, f := range r.Findings {
+			line := f.Line
+			if line <= 0 {
+				line = 1
+			}
+			title := f.Rule
+			if r.Name != "" {
+				title = fmt.Sprintf("%s: %s", r.Name, f.Rule)
+			}
+			if _, err := fmt.Fprintf(w, "::error file=%s,line=%d,title=%s::%s\n",
+				relativize(r.BasePath, f.Path), line, title, f.Message); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type jsonFinding struct {
+	Profile  string `json:"profile,omitempty"`
+	Path     string `json:"path"`
+	Line     int    `json:"line,omitempty"`
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+type jsonReport struct {
+	Findings []jsonFinding `json:"findings"`
+}
+
+func writeJSON(w io.Writer, results []ProfileResult) error {
+	report := jsonReport{Findings: []jsonFinding{}}
+	for _, r := range results {
+		for _, f := range r.Findings {
+			report.Findings = append(report.Findings, jsonFinding{
+				Profile:  r.Name,
+				Path:     relativize(r.BasePath, f.Path),
+				Line:     f.Line,
+				Rule:     f.Rule,
+				Severity: f.Severity,
+				Message:  f.Message,
+			})
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// relativize returns path relative to base when possible, falling back to
+// path unchanged (e.g. when base is empty or the paths don't share a root).
+func relativize(base, path string) string {
+	if base == "" || path == "" {
+		return path
+	}
+	rel, err := filepath.Rel(base, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+const sarifSchema = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string    `json:"id"`
+	ShortDescription sarifText `json:"shortDescription"`
+}
+
+type sarifText struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifText       `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+func writeSARIF(w io.Writer, results []ProfileResult) error {
+	ruleIDs := make(map[string]bool)
+	sarifResults := []sarifResult{}
+
+	for _, r := range results {
+		for _, f := range r.Findings {
+			ruleIDs[f.Rule] = true
+
+			var region *sarifRegion
+			if f.Line > 0 {
+				region = &sarifRegion{StartLine: f.Line}
+			}
+
+			msg := f.Message
+			if r.Name != "" {
+				msg = prefixed(r.Name, f.Message)
+			}
+
+			sarifResults = append(sarifResults, sarifResult{
+				RuleID:  f.Rule,
+				Level:   sarifLevel(f.Severity),
+				Message: sarifText{Text: msg},
+				Locations: []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: relativize(r.BasePath, f.Path)},
+						Region:           region,
+					},
+				}},
+			})
+		}
+	}
+
+	ids := make([]string, 0, len(ruleIDs))
+	for id := range ruleIDs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	rules := make([]sarifRule, 0, len(ids))
+	for _, id := range ids {
+		rules = append(rules, sarifRule{ID: id, ShortDescription: sarifText{Text: id}})
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:  "miglint",
+				Rules: rules,
+			}},
+			Results: sarifResults,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+func sarifLevel(severity string) string {
+	switch severity {
+	case lint.SeverityError:
+		return "error"
+	case "warning":
+		return "warning"
+	case "note":
+		return "note"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,100 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/tetzng/miglint/internal/lint"
+)
+
+func sampleResults() []ProfileResult {
+	return []ProfileResult{
+		{
+			Name:     "",
+			BasePath: "/migrations",
+			Findings: []lint.Finding{
+				{Path: "/migrations/000001_create.up.sql", Rule: lint.RuleMissingDown, Severity: lint.SeverityError, Message: "missing down migration for version 1"},
+			},
+		},
+	}
+}
+
+func TestWriteTextMatchesMessage(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, Text, sampleResults()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, "missing down migration for version 1") {
+		t.Fatalf("expected message in text output, got %q", got)
+	}
+}
+
+func TestWriteJSONRoundTrips(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, JSON, sampleResults()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var report jsonReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	if len(report.Findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(report.Findings))
+	}
+	if report.Findings[0].Rule != lint.RuleMissingDown {
+		t.Fatalf("expected rule %q, got %q", lint.RuleMissingDown, report.Findings[0].Rule)
+	}
+	if report.Findings[0].Path != "000001_create.up.sql" {
+		t.Fatalf("expected path relative to BasePath, got %q", report.Findings[0].Path)
+	}
+}
+
+func TestWriteSARIFIncludesDriverAndRule(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, SARIF, sampleResults()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid SARIF output: %v", err)
+	}
+	if log.Version != "2.1.0" {
+		t.Fatalf("expected SARIF version 2.1.0, got %q", log.Version)
+	}
+	if len(log.Runs) != 1 || log.Runs[0].Tool.Driver.Name != "miglint" {
+		t.Fatalf("expected tool.driver.name = miglint, got %+v", log.Runs)
+	}
+	if len(log.Runs[0].Tool.Driver.Rules) != 1 || log.Runs[0].Tool.Driver.Rules[0].ID != lint.RuleMissingDown {
+		t.Fatalf("expected one rule entry for %q, got %+v", lint.RuleMissingDown, log.Runs[0].Tool.Driver.Rules)
+	}
+	if len(log.Runs[0].Results) != 1 {
+		t.Fatalf("expected one result, got %d", len(log.Runs[0].Results))
+	}
+}
+
+func TestWriteGitHubEmitsErrorAnnotation(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, GitHub, sampleResults()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := buf.String()
+	if !strings.HasPrefix(got, "::error ") {
+		t.Fatalf("expected a GitHub Actions error annotation, got %q", got)
+	}
+	if !strings.Contains(got, "missing down migration for version 1") {
+		t.Fatalf("expected message in annotation, got %q", got)
+	}
+}
+
+func TestValidRejectsUnknownFormat(t *testing.T) {
+	if Valid("yaml") {
+		t.Fatalf("expected yaml to be an invalid format")
+	}
+	if !Valid("") || !Valid(JSON) || !Valid(SARIF) || !Valid(GitHub) {
+		t.Fatalf("expected default and known formats to be valid")
+	}
+}
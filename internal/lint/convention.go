@@ -0,0 +1,234 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Convention is the naming/pairing scheme Lint expects migration files to
+// follow. The zero value (ConventionDefault) preserves the legacy
+// <VERSION>_<NAME>.(up|down).<ext> behavior handled directly in Lint.
+type Convention string
+
+const (
+	ConventionDefault Convention = ""       // <VERSION>_<NAME>.(up|down).<ext>
+	ConventionGoose   Convention = "goose"  // paired files, or single file with "-- +goose Up/Down" markers
+	ConventionFlyway  Convention = "flyway" // V<version>__<desc>.sql / U<version>__<desc>.sql (undo)
+	ConventionDbmate  Convention = "dbmate" // <timestamp>_<name>.sql with "-- migrate:up"/"-- migrate:down" markers
+	ConventionSqlx    Convention = "sqlx"   // <version>_<description>.up.sql / .down.sql
+	ConventionCustom  Convention = "custom" // cfg.CustomPattern, named groups version/name/direction/ext
+)
+
+var (
+	flywayPattern = regexp.MustCompile(`^([VU])([0-9]+(?:[._][0-9]+)*)__(.+)\.([A-Za-z0-9]+)$`)
+	dbmatePattern = regexp.MustCompile(`^([0-9]+)_(.+)\.([A-Za-z0-9]+)$`)
+)
+
+// conventionMatch is a naming-convention-agnostic view of a matched file.
+// direction is "up"/"down" for paired-file conventions, and empty for
+// single-file conventions whose direction is instead scanned out of the
+// file's contents (see scanDirectionMarkers).
+type conventionMatch struct {
+	version    string
+	name       string
+	ext        string
+	direction  string
+	singleFile bool
+}
+
+// matchFilename applies cfg.Convention to a file name, reporting the parsed
+// pieces or ok=false if it isn't a migration under this convention.
+func matchFilename(cfg Config, name string) (conventionMatch, bool, error) {
+	switch Convention(cfg.Convention) {
+	case ConventionFlyway:
+		m := flywayPattern.FindStringSubmatch(name)
+		if m == nil {
+			return conventionMatch{}, false, nil
+		}
+		direction := "up"
+		if m[1] == "U" {
+			direction = "down"
+		}
+		return conventionMatch{version: m[2], name: m[3], ext: m[4], direction: direction}, true, nil
+
+	case ConventionDbmate:
+		m := dbmatePattern.FindStringSubmatch(name)
+		if m == nil {
+			return conventionMatch{}, false, nil
+		}
+		return conventionMatch{version: m[1], name: m[2], ext: m[3], singleFile: true}, true, nil
+
+	case ConventionGoose:
+		if m := migratePattern.FindStringSubmatch(name); m != nil {
+			return conventionMatch{version: m[1], name: m[2], direction: m[3], ext: m[4]}, true, nil
+		}
+		if m := dbmatePattern.FindStringSubmatch(name); m != nil {
+			return conventionMatch{version: m[1], name: m[2], ext: m[3], singleFile: true}, true, nil
+		}
+		return conventionMatch{}, false, nil
+
+	case ConventionCustom:
+		re, err := regexp.Compile(cfg.CustomPattern)
+		if err != nil {
+			return conventionMatch{}, false, fmt.Errorf("invalid -custom-pattern: %v", err)
+		}
+		m := re.FindStringSubmatch(name)
+		if m == nil {
+			return conventionMatch{}, false, nil
+		}
+		cm := conventionMatch{}
+		for i, group := range re.SubexpNames() {
+			switch group {
+			case "version":
+				cm.version = m[i]
+			case "name":
+				cm.name = m[i]
+			case "direction":
+				cm.direction = m[i]
+			case "ext":
+				cm.ext = m[i]
+			}
+		}
+		cm.singleFile = cm.direction == ""
+		return cm, true, nil
+
+	case ConventionSqlx, ConventionDefault:
+		fallthrough
+	default:
+		m := migratePattern.FindStringSubmatch(name)
+		if m == nil {
+			return conventionMatch{}, false, nil
+		}
+		return conventionMatch{version: m[1], name: m[2], direction: m[3], ext: m[4]}, true, nil
+	}
+}
+
+// scanDirectionMarkers opens a single-file migration and reports which
+// direction markers it contains.
+func scanDirectionMarkers(cfg Config, fullPath string) (hasUp, hasDown bool, err error) {
+	data, err := os.ReadFile(fullPath)
+	if err != nil {
+		return false, false, err
+	}
+	content := string(data)
+
+	switch Convention(cfg.Convention) {
+	case ConventionDbmate:
+		return strings.Contains(content, "-- migrate:up"), strings.Contains(content, "-- migrate:down"), nil
+	case ConventionGoose:
+		return strings.Contains(content, "-- +goose Up"), strings.Contains(content, "-- +goose Down"), nil
+	default:
+		hasUp = strings.Contains(content, "-- migrate:up") || strings.Contains(content, "-- +goose Up")
+		hasDown = strings.Contains(content, "-- migrate:down") || strings.Contains(content, "-- +goose Down")
+		return hasUp, hasDown, nil
+	}
+}
+
+// parseVersionKey converts a convention's version text (which may use dots
+// or underscores as separators, e.g. flyway's "1.2") into the int64 key
+// used for grouping/sorting.
+func parseVersionKey(versionStr string) (int64, error) {
+	cleaned := strings.NewReplacer(".", "", "_", "").Replace(versionStr)
+	return strconv.ParseInt(cleaned, 10, 64)
+}
+
+// shouldCheckDigits reports whether the Digits check should run for cfg's
+// convention. Timestamp-based conventions (dbmate's 14-digit versions) skip
+// it unless the caller explicitly set Digits.
+func shouldCheckDigits(cfg Config) bool {
+	if Convention(cfg.Convention) == ConventionDbmate && !cfg.DigitsExplicit {
+		return false
+	}
+	return true
+}
+
+// processConventionFile handles a single file under a non-default naming
+// convention: matching the filename, and for single-file conventions,
+// scanning the file's contents to synthesize virtual up/down Migration
+// entries so the existing duplicate/pairing/StrictNameMatch checks in Lint
+// keep working unmodified.
+func processConventionFile(cfg Config, name, fullPath string, lintErrors *[]Finding, excludedVersions map[int64]bool, versions map[int64]*VersionGroup, versionKeys *[]int64) error {
+	cm, ok, err := matchFilename(cfg, name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if cfg.StrictPattern {
+			*lintErrors = append(*lintErrors, newFinding(fullPath, RuleUnmatchedFile,
+				fmt.Sprintf("unmatched file: %s does not match the %s naming convention", fullPath, cfg.Convention)))
+		}
+		return nil
+	}
+
+	if cfg.Ext != "" && !extMatches(cfg.Ext, cm.ext, cm.ext) {
+		if cfg.EnforceExt {
+			*lintErrors = append(*lintErrors, newFinding(fullPath, RuleExtMismatch, formatExtMismatch(fullPath, cfg.Ext, cm.ext, cm.ext)))
+		}
+		return nil
+	}
+
+	version, parseErr := parseVersionKey(cm.version)
+	if parseErr != nil {
+		*lintErrors = append(*lintErrors, newFinding(fullPath, RuleVersionParse, fmt.Sprintf("version parse error in %s: %v", fullPath, parseErr)))
+		return nil
+	}
+	if excludedVersions[version] {
+		return nil
+	}
+
+	if cfg.Digits > 0 && shouldCheckDigits(cfg) && len(cm.version) != cfg.Digits {
+		*lintErrors = append(*lintErrors, newFinding(fullPath, RuleDigitsMismatch,
+			fmt.Sprintf("digits mismatch: %s has VERSION length %d, expected %d", fullPath, len(cm.version), cfg.Digits)))
+	}
+
+	group := versions[version]
+	if group == nil {
+		group = &VersionGroup{}
+		versions[version] = group
+		*versionKeys = append(*versionKeys, version)
+	}
+
+	if !cm.singleFile {
+		migration := newConventionMigration(fullPath, name, cm, version, cm.direction)
+		if cm.direction == "up" {
+			group.Up = append(group.Up, migration)
+		} else {
+			group.Down = append(group.Down, migration)
+		}
+		return nil
+	}
+
+	hasUp, hasDown, readErr := scanDirectionMarkers(cfg, fullPath)
+	if readErr != nil {
+		*lintErrors = append(*lintErrors, newFinding(fullPath, RuleReadError, fmt.Sprintf("failed to read %s: %v", fullPath, readErr)))
+		return nil
+	}
+	if !hasUp && !hasDown {
+		*lintErrors = append(*lintErrors, newFinding(fullPath, RuleMissingMarkers,
+			fmt.Sprintf("no up/down markers found in %s", fullPath)))
+		return nil
+	}
+	if hasUp {
+		group.Up = append(group.Up, newConventionMigration(fullPath, name, cm, version, "up"))
+	}
+	if hasDown {
+		group.Down = append(group.Down, newConventionMigration(fullPath, name, cm, version, "down"))
+	}
+	return nil
+}
+
+func newConventionMigration(fullPath, name string, cm conventionMatch, version int64, direction string) *Migration {
+	return &Migration{
+		Path:       fullPath,
+		FileName:   name,
+		VersionStr: cm.version,
+		Version:    version,
+		NamePart:   cm.name,
+		Direction:  direction,
+		ExtPart:    cm.ext,
+		FinalExt:   finalExtension(name),
+	}
+}
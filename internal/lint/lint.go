@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -27,6 +28,87 @@ type Config struct {
 	RequireDown     bool
 	StrictNameMatch bool
 	StrictPattern   bool
+
+	// Recursive enables descending into subdirectories of Path. When false,
+	// only files directly under Path are considered (legacy behavior).
+	Recursive bool
+	// IncludePatterns is an allow-list of doublestar-style globs matched
+	// against the path relative to Path. A file is kept iff it matches at
+	// least one include pattern, or no include patterns are given.
+	IncludePatterns []string
+	// ExcludePatterns are doublestar-style globs matched against the path
+	// relative to Path. A directory matching an exclude pattern is pruned
+	// from the walk entirely.
+	ExcludePatterns []string
+	// ExcludePaths are exact paths (relative to Path) to skip, in addition
+	// to ExcludePatterns.
+	ExcludePaths []string
+	// ExcludeVersions lists migration versions to ignore entirely, even if
+	// their files would otherwise match.
+	ExcludeVersions []int64
+
+	// Convention selects the migration naming/pairing scheme. Empty (the
+	// default) keeps the legacy <VERSION>_<NAME>.(up|down).<ext> behavior;
+	// see the Convention* constants for the other supported presets.
+	Convention string
+	// CustomPattern is a regex with named capture groups "version", "name",
+	// "direction" (optional, omit for single-file conventions) and "ext",
+	// used when Convention is ConventionCustom.
+	CustomPattern string
+	// DigitsExplicit records whether Digits was deliberately set (via flag
+	// or config file) rather than left at its zero value. Timestamp-based
+	// conventions (e.g. dbmate's 14-digit versions) skip the Digits check
+	// unless this is true, so a stray default doesn't misfire on them.
+	DigitsExplicit bool
+
+	// LintSQL enables the content-analysis pass (see internal/sqlcheck)
+	// that inspects .sql migration bodies for reversibility and
+	// destructiveness problems, in addition to the filename-only checks.
+	LintSQL bool
+	// RequireTx, with LintSQL, flags up migrations that don't wrap their
+	// statements in a BEGIN/COMMIT transaction.
+	RequireTx bool
+	// Dialect enables dialect-specific SQL advice; currently only
+	// "postgres" is recognized.
+	Dialect string
+}
+
+// Finding is a single lint result. Path and Line identify where it applies
+// (Line is 0 when the finding isn't anchored to a specific source line);
+// Rule is a stable machine-readable identifier (see the Rule* constants);
+// Severity is currently always "error" since any Finding fails the lint run.
+type Finding struct {
+	Path     string
+	Line     int
+	Rule     string
+	Severity string
+	Message  string
+}
+
+// Rule identifiers used in Finding.Rule.
+const (
+	RuleSymlinkStat     = "symlink-stat-error"
+	RuleExtMismatch     = "ext-mismatch"
+	RuleUnmatchedFile   = "unmatched-file"
+	RuleVersionParse    = "version-parse-error"
+	RuleDigitsMismatch  = "digits-mismatch"
+	RuleDuplicateUp     = "duplicate-up"
+	RuleDuplicateDown   = "duplicate-down"
+	RuleMissingDown     = "missing-down"
+	RuleMissingUp       = "missing-up"
+	RuleNameExtMismatch = "name-ext-mismatch"
+	RuleSQLContent      = "sql-content"
+	RuleGap             = "gap"
+	RuleWalkError       = "walk-error"
+	RuleReadError       = "read-error"
+	RuleMissingMarkers  = "missing-direction-markers"
+)
+
+// SeverityError is the Severity every Finding currently carries.
+const SeverityError = "error"
+
+func newFinding(path, rule, message string) Finding {
+	return Finding{Path: path, Rule: rule, Severity: SeverityError, Message: message}
 }
 
 // Migration represents a single parsed migration file.
@@ -47,30 +129,37 @@ type VersionGroup struct {
 	Down []*Migration
 }
 
+// fileEntry is a single candidate file gathered by the directory walk,
+// independent of whether it came from a flat os.ReadDir or a recursive walk.
+type fileEntry struct {
+	name     string // base file name
+	fullPath string
+	entry    os.DirEntry
+}
+
 // Lint inspects migration files under cfg.Path and returns lint errors (non-fatal)
 // or a fatal error (IO/config issues). Caller handles exit codes/output.
-func Lint(cfg Config) ([]string, error) {
+func Lint(cfg Config) ([]Finding, error) {
 	if err := ensureDir(cfg.Path); err != nil {
 		return nil, err
 	}
 
-	entries, err := os.ReadDir(cfg.Path)
+	files, lintErrors, err := gatherFiles(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read path: %v", err)
+		return nil, err
 	}
 
-	var lintErrors []string
 	versions := make(map[int64]*VersionGroup)
 	var versionKeys []int64
+	excludedVersions := make(map[int64]bool, len(cfg.ExcludeVersions))
+	for _, v := range cfg.ExcludeVersions {
+		excludedVersions[v] = true
+	}
 
-	for _, entry := range entries {
-		if entry.IsDir() {
-			// Only files directly under the specified path are considered.
-			continue
-		}
-
-		name := entry.Name()
-		fullPath := filepath.Join(cfg.Path, name)
+	for _, f := range files {
+		name := f.name
+		fullPath := f.fullPath
+		entry := f.entry
 		if entry.Type()&os.ModeType != 0 {
 			if entry.Type()&os.ModeSymlink == 0 {
 				// Skip non-regular files (device/etc.).
@@ -78,7 +167,7 @@ func Lint(cfg Config) ([]string, error) {
 			}
 			info, err := os.Stat(fullPath)
 			if err != nil {
-				lintErrors = append(lintErrors, fmt.Sprintf("failed to stat symlink: %s: %v", fullPath, err))
+				lintErrors = append(lintErrors, newFinding(fullPath, RuleSymlinkStat, fmt.Sprintf("failed to stat symlink: %s: %v", fullPath, err)))
 				continue
 			}
 			if !info.Mode().IsRegular() {
@@ -87,6 +176,13 @@ func Lint(cfg Config) ([]string, error) {
 			}
 		}
 
+		if cfg.Convention != "" {
+			if err := processConventionFile(cfg, name, fullPath, &lintErrors, excludedVersions, versions, &versionKeys); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
 		finalExt := finalExtension(name)
 		prefixMatch := migrationPrefixRe.FindStringSubmatch(name)
 		extPartOpt := ""
@@ -106,14 +202,14 @@ func Lint(cfg Config) ([]string, error) {
 		if cfg.Ext != "" && cfg.EnforceExt && !extMatches(cfg.Ext, finalExtForMatch, extPartOpt) && (matches != nil || isMigrationLike || hasDirection) {
 			// Avoid double-reporting when strict-pattern will already flag it as unmatched.
 			if matches != nil || !cfg.StrictPattern {
-				lintErrors = append(lintErrors, formatExtMismatch(fullPath, cfg.Ext, finalExtForMatch, extPartOpt))
+				lintErrors = append(lintErrors, newFinding(fullPath, RuleExtMismatch, formatExtMismatch(fullPath, cfg.Ext, finalExtForMatch, extPartOpt)))
 			}
 		}
 
 		if matches == nil {
 			if isCandidate(name, finalExtForMatch, extPartOpt, isMigrationLike, hasDirection, cfg) {
-				lintErrors = append(lintErrors,
-					fmt.Sprintf("unmatched file: %s does not match <VERSION>_<NAME>.(up|down).<ext>", fullPath))
+				lintErrors = append(lintErrors, newFinding(fullPath, RuleUnmatchedFile,
+					fmt.Sprintf("unmatched file: %s does not match <VERSION>_<NAME>.(up|down).<ext>", fullPath)))
 			}
 			continue
 		}
@@ -131,13 +227,17 @@ func Lint(cfg Config) ([]string, error) {
 
 		version, parseErr := strconv.ParseInt(versionStr, 10, 64)
 		if parseErr != nil {
-			lintErrors = append(lintErrors, fmt.Sprintf("version parse error in %s: %v", fullPath, parseErr))
+			lintErrors = append(lintErrors, newFinding(fullPath, RuleVersionParse, fmt.Sprintf("version parse error in %s: %v", fullPath, parseErr)))
+			continue
+		}
+
+		if excludedVersions[version] {
 			continue
 		}
 
 		if cfg.Digits > 0 && len(versionStr) != cfg.Digits {
-			lintErrors = append(lintErrors,
-				fmt.Sprintf("digits mismatch: %s has VERSION length %d, expected %d", fullPath, len(versionStr), cfg.Digits))
+			lintErrors = append(lintErrors, newFinding(fullPath, RuleDigitsMismatch,
+				fmt.Sprintf("digits mismatch: %s has VERSION length %d, expected %d", fullPath, len(versionStr), cfg.Digits)))
 		}
 
 		group := versions[version]
@@ -170,22 +270,22 @@ func Lint(cfg Config) ([]string, error) {
 	for _, version := range versionKeys {
 		group := versions[version]
 		if len(group.Up) > 1 {
-			lintErrors = append(lintErrors,
-				fmt.Sprintf("duplicate up migrations for version %d: %s", version, joinPaths(group.Up)))
+			lintErrors = append(lintErrors, newFinding(group.Up[0].Path, RuleDuplicateUp,
+				fmt.Sprintf("duplicate up migrations for version %d: %s", version, joinPaths(group.Up))))
 		}
 		if len(group.Down) > 1 {
-			lintErrors = append(lintErrors,
-				fmt.Sprintf("duplicate down migrations for version %d: %s", version, joinPaths(group.Down)))
+			lintErrors = append(lintErrors, newFinding(group.Down[0].Path, RuleDuplicateDown,
+				fmt.Sprintf("duplicate down migrations for version %d: %s", version, joinPaths(group.Down))))
 		}
 
 		if cfg.RequireDown {
 			if len(group.Up) > 0 && len(group.Down) == 0 {
-				lintErrors = append(lintErrors,
-					fmt.Sprintf("missing down migration for version %d", version))
+				lintErrors = append(lintErrors, newFinding(group.Up[0].Path, RuleMissingDown,
+					fmt.Sprintf("missing down migration for version %d", version)))
 			}
 			if len(group.Down) > 0 && len(group.Up) == 0 {
-				lintErrors = append(lintErrors,
-					fmt.Sprintf("missing up migration for version %d", version))
+				lintErrors = append(lintErrors, newFinding(group.Down[0].Path, RuleMissingUp,
+					fmt.Sprintf("missing up migration for version %d", version)))
 			}
 		}
 
@@ -193,10 +293,14 @@ func Lint(cfg Config) ([]string, error) {
 			up := group.Up[0]
 			down := group.Down[0]
 			if up.NamePart != down.NamePart || up.ExtPart != down.ExtPart {
-				lintErrors = append(lintErrors,
-					fmt.Sprintf("name/ext mismatch for version %d: up=%s, down=%s", version, up.FileName, down.FileName))
+				lintErrors = append(lintErrors, newFinding(up.Path, RuleNameExtMismatch,
+					fmt.Sprintf("name/ext mismatch for version %d: up=%s, down=%s", version, up.FileName, down.FileName)))
 			}
 		}
+
+		if cfg.LintSQL {
+			lintErrors = append(lintErrors, sqlContentFindings(cfg, group)...)
+		}
 	}
 
 	if cfg.NoGaps && len(versionKeys) > 0 {
@@ -207,9 +311,11 @@ func Lint(cfg Config) ([]string, error) {
 				missingStart := prev + 1
 				missingEnd := cur - 1
 				if missingStart == missingEnd {
-					lintErrors = append(lintErrors, fmt.Sprintf("missing version %d (between %d and %d)", missingStart, prev, cur))
+					lintErrors = append(lintErrors, newFinding("", RuleGap,
+						fmt.Sprintf("missing version %d (between %d and %d)", missingStart, prev, cur)))
 				} else {
-					lintErrors = append(lintErrors, fmt.Sprintf("missing versions %d..%d (between %d and %d)", missingStart, missingEnd, prev, cur))
+					lintErrors = append(lintErrors, newFinding("", RuleGap,
+						fmt.Sprintf("missing versions %d..%d (between %d and %d)", missingStart, missingEnd, prev, cur)))
 				}
 			}
 		}
@@ -218,6 +324,155 @@ func Lint(cfg Config) ([]string, error) {
 	return lintErrors, nil
 }
 
+// gatherFiles walks cfg.Path and returns the candidate files to lint. When
+// cfg.Recursive is false only files directly under cfg.Path are considered;
+// when true it descends into subdirectories, pruning directories that match
+// ExcludePatterns/ExcludePaths. In both modes only files allowed by
+// IncludePatterns/ExcludePatterns/ExcludePaths are kept. Non-fatal problems
+// (e.g. an unreadable subdirectory) are returned alongside the files as lint
+// errors.
+func gatherFiles(cfg Config) ([]fileEntry, []Finding, error) {
+	if !cfg.Recursive {
+		entries, err := os.ReadDir(cfg.Path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read path: %v", err)
+		}
+
+		var files []fileEntry
+		for _, entry := range entries {
+			if entry.IsDir() {
+				// Only files directly under the specified path are considered.
+				continue
+			}
+			if matchesExclude(cfg, entry.Name()) || !matchesInclude(cfg, entry.Name()) {
+				continue
+			}
+			files = append(files, fileEntry{
+				name:     entry.Name(),
+				fullPath: filepath.Join(cfg.Path, entry.Name()),
+				entry:    entry,
+			})
+		}
+		return files, nil, nil
+	}
+
+	var files []fileEntry
+	var lintErrors []Finding
+	walkErr := filepath.WalkDir(cfg.Path, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			lintErrors = append(lintErrors, newFinding(path, RuleWalkError, fmt.Sprintf("failed to walk: %s: %v", path, err)))
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if path == cfg.Path {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(cfg.Path, path)
+		if err != nil {
+			return nil
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		if d.IsDir() {
+			if matchesExclude(cfg, relPath) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if matchesExclude(cfg, relPath) {
+			return nil
+		}
+		if !matchesInclude(cfg, relPath) {
+			return nil
+		}
+
+		files = append(files, fileEntry{
+			name:     d.Name(),
+			fullPath: path,
+			entry:    d,
+		})
+		return nil
+	})
+	if walkErr != nil {
+		return nil, nil, fmt.Errorf("failed to walk path: %v", walkErr)
+	}
+
+	return files, lintErrors, nil
+}
+
+func matchesExclude(cfg Config, relPath string) bool {
+	for _, p := range cfg.ExcludePaths {
+		if filepath.ToSlash(p) == relPath {
+			return true
+		}
+	}
+	return matchGlobList(cfg.ExcludePatterns, relPath)
+}
+
+func matchesInclude(cfg Config, relPath string) bool {
+	if len(cfg.IncludePatterns) == 0 {
+		return true
+	}
+	return matchGlobList(cfg.IncludePatterns, relPath)
+}
+
+// matchGlobList applies gitignore-style layering: patterns are evaluated in
+// order, a match sets the result, and a leading "!" on a later pattern
+// negates a previous match.
+func matchGlobList(patterns []string, relPath string) bool {
+	matched := false
+	for _, pat := range patterns {
+		neg := false
+		p := pat
+		if strings.HasPrefix(p, "!") {
+			neg = true
+			p = p[1:]
+		}
+		if globMatch(p, relPath) {
+			matched = !neg
+		}
+	}
+	return matched
+}
+
+// globMatch reports whether name matches a doublestar-style glob pattern:
+// "**" matches any number of path segments (including zero), and each
+// remaining segment is matched with path.Match semantics.
+func globMatch(pattern, name string) bool {
+	patSegs := strings.Split(filepath.ToSlash(pattern), "/")
+	nameSegs := strings.Split(filepath.ToSlash(name), "/")
+	return globSegMatch(patSegs, nameSegs)
+}
+
+func globSegMatch(patSegs, nameSegs []string) bool {
+	if len(patSegs) == 0 {
+		return len(nameSegs) == 0
+	}
+
+	if patSegs[0] == "**" {
+		if globSegMatch(patSegs[1:], nameSegs) {
+			return true
+		}
+		if len(nameSegs) == 0 {
+			return false
+		}
+		return globSegMatch(patSegs, nameSegs[1:])
+	}
+
+	if len(nameSegs) == 0 {
+		return false
+	}
+	ok, err := path.Match(patSegs[0], nameSegs[0])
+	if err != nil || !ok {
+		return false
+	}
+	return globSegMatch(patSegs[1:], nameSegs[1:])
+}
+
 func ensureDir(dir string) error {
 	info, err := os.Stat(dir)
 	if err != nil {
@@ -217,24 +217,347 @@ func TestSymlinkedMigrationIsProcessed(t *testing.T) {
 	assertAnyContains(t, errs, "missing down migration for version 1")
 }
 
+func TestRecursiveGathersNestedVersions(t *testing.T) {
+	dir := t.TempDir()
+	touch(t, filepath.Join(dir, "202401"), "000001_create_users.up.sql")
+	touch(t, filepath.Join(dir, "202401"), "000001_create_users.down.sql")
+	touch(t, filepath.Join(dir, "202402"), "000002_add_index.up.sql")
+	touch(t, filepath.Join(dir, "202402"), "000002_add_index.down.sql")
+
+	cfg := Config{Path: dir, Recursive: true, RequireDown: true}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("expected no lint errors, got %v", errs)
+	}
+}
+
+func TestRecursiveSkipsExcludedDirs(t *testing.T) {
+	dir := t.TempDir()
+	touch(t, filepath.Join(dir, "202401"), "000001_create_users.up.sql")
+	touch(t, filepath.Join(dir, "202401"), "000001_create_users.down.sql")
+	touch(t, filepath.Join(dir, "testdata"), "000099_bad.up.sql")
+	touch(t, filepath.Join(dir, "vendor", "lib"), "000098_bad.up.sql")
+
+	cfg := Config{
+		Path:            dir,
+		Recursive:       true,
+		RequireDown:     true,
+		ExcludePatterns: []string{"testdata/**", "vendor/**"},
+	}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("expected excluded dirs to be pruned, got %v", errs)
+	}
+}
+
+func TestRecursiveIncludeAllowList(t *testing.T) {
+	dir := t.TempDir()
+	touch(t, filepath.Join(dir, "service_a"), "000001_create_users.up.sql")
+	touch(t, filepath.Join(dir, "service_b"), "000002_create_orders.up.sql")
+
+	cfg := Config{
+		Path:            dir,
+		Recursive:       true,
+		IncludePatterns: []string{"service_a/**"},
+		RequireDown:     true,
+	}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertAnyContains(t, errs, "missing down migration for version 1")
+	if anyContains(errs, "version 2") {
+		t.Fatalf("service_b should have been excluded by the include allow-list: %v", errs)
+	}
+}
+
+func TestRecursiveExcludeVersions(t *testing.T) {
+	dir := t.TempDir()
+	touch(t, dir, "000001_a.up.sql")
+	touch(t, dir, "000002_b.up.sql")
+	touch(t, dir, "000002_b.down.sql")
+
+	cfg := Config{Path: dir, Recursive: true, RequireDown: true, ExcludeVersions: []int64{1}}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("expected version 1 to be excluded, got %v", errs)
+	}
+}
+
+func TestFlywayConventionPairs(t *testing.T) {
+	dir := t.TempDir()
+	touch(t, dir, "V1.1__create_users.sql")
+	touch(t, dir, "U1.1__create_users.sql")
+
+	cfg := Config{Path: dir, Convention: "flyway", RequireDown: true}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("expected no lint errors, got %v", errs)
+	}
+}
+
+func TestFlywayConventionMissingDown(t *testing.T) {
+	dir := t.TempDir()
+	touch(t, dir, "V2__add_index.sql")
+
+	cfg := Config{Path: dir, Convention: "flyway", RequireDown: true}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertAnyContains(t, errs, "missing down migration for version 2")
+}
+
+func TestFlywayConventionEnforceExtReportsMismatch(t *testing.T) {
+	dir := t.TempDir()
+	touch(t, dir, "V1__create_users.txt")
+
+	cfg := Config{Path: dir, Convention: "flyway", Ext: "sql", EnforceExt: true}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertAnyContains(t, errs, "extension mismatch")
+}
+
+func TestDbmateConventionSingleFileMarkers(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "20240102030405_create_users.sql", "-- migrate:up\nCREATE TABLE users (id int);\n-- migrate:down\nDROP TABLE users;\n")
+
+	cfg := Config{Path: dir, Convention: "dbmate", RequireDown: true}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("expected no lint errors, got %v", errs)
+	}
+}
+
+func TestDbmateConventionSkipsDigitsByDefault(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "20240102030405_create_users.sql", "-- migrate:up\n-- migrate:down\n")
+
+	cfg := Config{Path: dir, Convention: "dbmate", Digits: 6}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if anyContains(errs, "digits mismatch") {
+		t.Fatalf("expected digits check to be skipped for dbmate unless DigitsExplicit, got %v", errs)
+	}
+}
+
+func TestDbmateConventionChecksDigitsWhenExplicit(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "20240102030405_create_users.sql", "-- migrate:up\n-- migrate:down\n")
+
+	cfg := Config{Path: dir, Convention: "dbmate", Digits: 6, DigitsExplicit: true}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertAnyContains(t, errs, "digits mismatch")
+}
+
+func TestGooseConventionSingleFileMarkers(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "00001_create_users.sql", "-- +goose Up\nCREATE TABLE users (id int);\n-- +goose Down\nDROP TABLE users;\n")
+
+	cfg := Config{Path: dir, Convention: "goose", RequireDown: true}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("expected no lint errors, got %v", errs)
+	}
+}
+
+func TestGooseConventionPairedFiles(t *testing.T) {
+	dir := t.TempDir()
+	touch(t, dir, "00001_create_users.up.sql")
+	touch(t, dir, "00001_create_users.down.sql")
+
+	cfg := Config{Path: dir, Convention: "goose", RequireDown: true}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("expected no lint errors, got %v", errs)
+	}
+}
+
+func TestCustomConventionPattern(t *testing.T) {
+	dir := t.TempDir()
+	touch(t, dir, "users-create-001-up.sql")
+	touch(t, dir, "users-create-001-down.sql")
+
+	cfg := Config{
+		Path:          dir,
+		Convention:    "custom",
+		CustomPattern: `^(?P<name>[a-z]+)-(?P<sub>[a-z]+)-(?P<version>[0-9]+)-(?P<direction>up|down)\.(?P<ext>[a-z]+)$`,
+		RequireDown:   true,
+	}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("expected no lint errors, got %v", errs)
+	}
+}
+
+func TestLintSQLFlagsMissingDropTable(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "000001_create_users.up.sql", "CREATE TABLE users (id int);")
+	writeFile(t, dir, "000001_create_users.down.sql", "")
+
+	cfg := Config{Path: dir, LintSQL: true}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertAnyContains(t, errs, "content: ")
+	assertAnyContains(t, errs, "no corresponding DROP TABLE")
+}
+
+func TestLintSQLPassesWhenReversible(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "000001_create_users.up.sql", "CREATE TABLE users (id int);")
+	writeFile(t, dir, "000001_create_users.down.sql", "DROP TABLE users;")
+
+	cfg := Config{Path: dir, LintSQL: true}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("expected no lint errors, got %v", errs)
+	}
+}
+
+func TestLintSQLFlagsTruncateInDown(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "000001_seed.up.sql", "INSERT INTO users VALUES (1);")
+	writeFile(t, dir, "000001_seed.down.sql", "TRUNCATE users;")
+
+	cfg := Config{Path: dir, LintSQL: true}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertAnyContains(t, errs, "irreversible and destructive")
+}
+
+func TestLintSQLRequireTx(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "000001_create_users.up.sql", "CREATE TABLE users (id int);")
+	writeFile(t, dir, "000001_create_users.down.sql", "DROP TABLE users;")
+
+	cfg := Config{Path: dir, LintSQL: true, RequireTx: true}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertAnyContains(t, errs, "missing a transaction wrapper")
+}
+
+func TestLintSQLFindingsReportLineNumbers(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "000001_create_users.up.sql", "\nCREATE TABLE users (id int);")
+	writeFile(t, dir, "000001_create_users.down.sql", "")
+
+	cfg := Config{Path: dir, LintSQL: true}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	found := false
+	for _, f := range errs {
+		if f.Rule == RuleSQLContent && f.Line == 2 {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a sql-content finding on line 2, got %v", errs)
+	}
+}
+
+func TestLintSQLSingleFileFindingLineAccountsForMarkerOffset(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "20240102030405_create_users.sql",
+		"-- migrate:up\nCREATE TABLE users (id int);\n-- migrate:down\n")
+
+	cfg := Config{Path: dir, Convention: "dbmate", LintSQL: true}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	found := false
+	for _, f := range errs {
+		if f.Rule == RuleSQLContent && f.Line == 2 {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a sql-content finding anchored to line 2 (after the up marker), got %v", errs)
+	}
+}
+
+func TestLintSQLIgnoresNonSQLExt(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "000001_create_users.up.txt", "CREATE TABLE users (id int);")
+
+	cfg := Config{Path: dir, LintSQL: true}
+	errs, err := Lint(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("expected no content findings for non-.sql files, got %v", errs)
+	}
+}
+
 func touch(t *testing.T, dir, name string) {
 	t.Helper()
+	writeFile(t, dir, name, "")
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", dir, err)
+	}
 	path := filepath.Join(dir, name)
-	if err := os.WriteFile(path, []byte(""), 0o644); err != nil {
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
 		t.Fatalf("write file %s: %v", path, err)
 	}
 }
 
-func assertAnyContains(t *testing.T, arr []string, substr string) {
+func assertAnyContains(t *testing.T, findings []Finding, substr string) {
 	t.Helper()
-	if !anyContains(arr, substr) {
-		t.Fatalf("expected any string to contain %q, got %v", substr, arr)
+	if !anyContains(findings, substr) {
+		t.Fatalf("expected any finding message to contain %q, got %v", substr, findings)
 	}
 }
 
-func anyContains(arr []string, substr string) bool {
-	for _, s := range arr {
-		if strings.Contains(s, substr) {
+func anyContains(findings []Finding, substr string) bool {
+	for _, f := range findings {
+		if strings.Contains(f.Message, substr) {
 			return true
 		}
 	}
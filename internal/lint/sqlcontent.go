@@ -0,0 +1,95 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tetzng/miglint/internal/sqlcheck"
+)
+
+// sqlContentFindings runs the sqlcheck content-analysis pass over a
+// version's up/down pair and formats each finding as
+// "content: <path>:<stmt_index>: <message>" to slot into the existing
+// lintErrors slice.
+func sqlContentFindings(cfg Config, group *VersionGroup) []Finding {
+	if len(group.Up) != 1 {
+		// Duplicates are already reported separately; content analysis needs
+		// an unambiguous up file to anchor findings to.
+		return nil
+	}
+	up := group.Up[0]
+	if !strings.EqualFold(extOrFinal(up), "sql") {
+		return nil
+	}
+
+	var downPath string
+	if len(group.Down) == 1 {
+		downPath = group.Down[0].Path
+	}
+
+	upSQL, downSQL, upLineOffset, downLineOffset, err := readDirectionalSQL(up.Path, downPath)
+	if err != nil {
+		return []Finding{newFinding(up.Path, RuleSQLContent, fmt.Sprintf("content: %s", err))}
+	}
+
+	findings := sqlcheck.AnalyzePair(upSQL, downSQL, sqlcheck.Options{
+		RequireTx: cfg.RequireTx,
+		Dialect:   cfg.Dialect,
+	})
+
+	out := make([]Finding, 0, len(findings))
+	for _, f := range findings {
+		path := up.Path
+		lineOffset := upLineOffset
+		if f.Side == "down" {
+			path = downPath
+			lineOffset = downLineOffset
+		}
+		finding := newFinding(path, RuleSQLContent, fmt.Sprintf("content: %s:%d: %s", path, f.StmtIndex, f.Message))
+		if f.Line > 0 {
+			finding.Line = f.Line + lineOffset
+		}
+		out = append(out, finding)
+	}
+	return out
+}
+
+// readDirectionalSQL returns the up and down SQL bodies to analyze, plus the
+// number of lines preceding each section in its source file (always 0 for
+// paired files; for single-file conventions, the offsets from
+// sqlcheck.DirectionLineOffsets, which let a within-section Statement.Line be
+// translated back to the file's absolute line). For paired files it reads each independently; for single-file
+// conventions (upPath == downPath) it splits the shared file on its
+// direction markers.
+func readDirectionalSQL(upPath, downPath string) (upSQL, downSQL string, upLineOffset, downLineOffset int, err error) {
+	data, err := os.ReadFile(upPath)
+	if err != nil {
+		return "", "", 0, 0, fmt.Errorf("%s: failed to read migration: %v", upPath, err)
+	}
+	content := string(data)
+
+	if downPath == upPath {
+		upSQL, downSQL = sqlcheck.SplitDirections(content)
+		upLineOffset, downLineOffset = sqlcheck.DirectionLineOffsets(content)
+		return upSQL, downSQL, upLineOffset, downLineOffset, nil
+	}
+
+	upSQL = content
+	if downPath == "" {
+		return upSQL, "", 0, 0, nil
+	}
+
+	downData, err := os.ReadFile(downPath)
+	if err != nil {
+		return "", "", 0, 0, fmt.Errorf("%s: failed to read migration: %v", downPath, err)
+	}
+	return upSQL, string(downData), 0, 0, nil
+}
+
+func extOrFinal(m *Migration) string {
+	if m.ExtPart != "" {
+		return m.ExtPart
+	}
+	return m.FinalExt
+}
@@ -0,0 +1,301 @@
+// Package sqlcheck is a small tokenizer for migration SQL content — not a
+// full parser. It splits a file into statements, classifies a handful of
+// DDL/DML keywords (CREATE, ALTER, DROP, TRUNCATE, INSERT), and flags
+// common reversibility and destructiveness problems between an up and its
+// paired down migration.
+package sqlcheck
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Options controls which checks AnalyzePair runs.
+type Options struct {
+	// RequireTx reports up migrations that don't wrap their statements in a
+	// BEGIN/COMMIT transaction.
+	RequireTx bool
+	// Dialect enables dialect-specific advice; currently only "postgres" is
+	// recognized (flags SERIAL/AUTO_INCREMENT in favor of GENERATED ... AS IDENTITY).
+	Dialect string
+}
+
+// Finding is one content-lint issue. Side is "up" or "down", identifying
+// which file StmtIndex/Line refer to. Line is 1-based and best-effort (0 if
+// it couldn't be located).
+type Finding struct {
+	Side      string
+	StmtIndex int
+	Line      int
+	Message   string
+}
+
+// Statement is a single SQL statement split out of a migration file, with a
+// best-effort classification of its verb and the object it operates on.
+// Line is the 1-based source line Text's first rune starts on.
+type Statement struct {
+	Index  int
+	Text   string
+	Line   int
+	Verb   string // CREATE_TABLE, ALTER_ADD_COLUMN, ALTER_DROP_COLUMN, DROP_TABLE, DROP_DATABASE, TRUNCATE, INSERT, or "" if unrecognized
+	Table  string
+	Column string
+}
+
+var (
+	createTableRe  = regexp.MustCompile(`(?i)^CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?"?` + "`" + `?([a-zA-Z0-9_.]+)` + "`" + `?"?`)
+	dropTableRe    = regexp.MustCompile(`(?i)^DROP\s+TABLE\s+(?:IF\s+EXISTS\s+)?"?` + "`" + `?([a-zA-Z0-9_.]+)` + "`" + `?"?`)
+	dropDatabaseRe = regexp.MustCompile(`(?i)^DROP\s+DATABASE\s+`)
+	truncateRe     = regexp.MustCompile(`(?i)^TRUNCATE\s+(?:TABLE\s+)?"?` + "`" + `?([a-zA-Z0-9_.]+)` + "`" + `?"?`)
+	addColumnRe    = regexp.MustCompile(`(?i)^ALTER\s+TABLE\s+"?` + "`" + `?([a-zA-Z0-9_.]+)` + "`" + `?"?\s+ADD\s+(?:COLUMN\s+)?"?` + "`" + `?([a-zA-Z0-9_]+)` + "`" + `?"?`)
+	dropColumnRe   = regexp.MustCompile(`(?i)^ALTER\s+TABLE\s+"?` + "`" + `?([a-zA-Z0-9_.]+)` + "`" + `?"?\s+DROP\s+(?:COLUMN\s+)?"?` + "`" + `?([a-zA-Z0-9_]+)` + "`" + `?"?`)
+	insertRe       = regexp.MustCompile(`(?i)^INSERT\s+INTO\s+`)
+
+	txBeginRe  = regexp.MustCompile(`(?im)^\s*BEGIN\b`)
+	serialRe   = regexp.MustCompile(`(?i)\b(SERIAL|AUTO_INCREMENT)\b`)
+	upMarkerRe = regexp.MustCompile(`(?im)^--\s*(?:migrate:up|\+goose Up)\s*$`)
+
+	downMarkerRe = regexp.MustCompile(`(?im)^--\s*(?:migrate:down|\+goose Down)\s*$`)
+)
+
+// segment is a statement plus the index of its first rune in the original
+// source — so the statement's true starting position (after stripped
+// comments and leading whitespace) can still be located exactly.
+type segment struct {
+	text       string
+	runeOffset int // source rune index of text's first rune, or -1 if text is empty
+}
+
+// Split breaks sql into individual statements on ";", skipping "--" line
+// comments and treating anything between a pair of "$$" dollar-quote
+// delimiters (Postgres function bodies) as opaque so embedded semicolons
+// don't split the statement early.
+func Split(sql string) []string {
+	segs := splitSegments(sql)
+	out := make([]string, len(segs))
+	for i, s := range segs {
+		out[i] = s.text
+	}
+	return out
+}
+
+func splitSegments(sql string) []segment {
+	var segs []segment
+	var buf []rune
+	var bufIdx []int
+	inDollar := false
+
+	runes := []rune(sql)
+	flush := func() {
+		seg := makeSegment(buf, bufIdx)
+		if seg.text != "" {
+			segs = append(segs, seg)
+		}
+		buf = buf[:0]
+		bufIdx = bufIdx[:0]
+	}
+
+	for i := 0; i < len(runes); i++ {
+		if !inDollar && runes[i] == '-' && i+1 < len(runes) && runes[i+1] == '-' {
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			continue
+		}
+		if runes[i] == '$' && i+1 < len(runes) && runes[i+1] == '$' {
+			inDollar = !inDollar
+			buf = append(buf, '$', '$')
+			bufIdx = append(bufIdx, i, i+1)
+			i++
+			continue
+		}
+		if runes[i] == ';' && !inDollar {
+			flush()
+			continue
+		}
+		buf = append(buf, runes[i])
+		bufIdx = append(bufIdx, i)
+	}
+	flush()
+
+	return segs
+}
+
+// makeSegment trims leading/trailing whitespace from buf, using bufIdx (buf's
+// parallel source-rune-index slice) to recover the trimmed text's true
+// starting offset in the source.
+func makeSegment(buf []rune, bufIdx []int) segment {
+	start := 0
+	for start < len(buf) && isSQLSpace(buf[start]) {
+		start++
+	}
+	end := len(buf)
+	for end > start && isSQLSpace(buf[end-1]) {
+		end--
+	}
+	if start >= end {
+		return segment{runeOffset: -1}
+	}
+	return segment{text: string(buf[start:end]), runeOffset: bufIdx[start]}
+}
+
+func isSQLSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
+
+// Parse splits sql and classifies each resulting statement.
+func Parse(sql string) []Statement {
+	segs := splitSegments(sql)
+	out := make([]Statement, 0, len(segs))
+	runes := []rune(sql)
+	for i, seg := range segs {
+		text := seg.text
+		stmt := Statement{Index: i, Text: text}
+		if seg.runeOffset >= 0 {
+			stmt.Line = 1 + strings.Count(string(runes[:seg.runeOffset]), "\n")
+		}
+		switch {
+		case createTableRe.MatchString(text):
+			m := createTableRe.FindStringSubmatch(text)
+			stmt.Verb, stmt.Table = "CREATE_TABLE", m[1]
+		case dropDatabaseRe.MatchString(text):
+			stmt.Verb = "DROP_DATABASE"
+		case dropTableRe.MatchString(text):
+			m := dropTableRe.FindStringSubmatch(text)
+			stmt.Verb, stmt.Table = "DROP_TABLE", m[1]
+		case truncateRe.MatchString(text):
+			m := truncateRe.FindStringSubmatch(text)
+			stmt.Verb, stmt.Table = "TRUNCATE", m[1]
+		case addColumnRe.MatchString(text):
+			m := addColumnRe.FindStringSubmatch(text)
+			stmt.Verb, stmt.Table, stmt.Column = "ALTER_ADD_COLUMN", m[1], m[2]
+		case dropColumnRe.MatchString(text):
+			m := dropColumnRe.FindStringSubmatch(text)
+			stmt.Verb, stmt.Table, stmt.Column = "ALTER_DROP_COLUMN", m[1], m[2]
+		case insertRe.MatchString(text):
+			stmt.Verb = "INSERT"
+		}
+		out = append(out, stmt)
+	}
+	return out
+}
+
+// SplitDirections splits a single-file migration's content into its up and
+// down sections, delimited by "-- migrate:up"/"-- migrate:down" (dbmate) or
+// "-- +goose Up"/"-- +goose Down" (goose) marker lines. A side not present
+// in content comes back empty.
+func SplitDirections(content string) (up, down string) {
+	upIdx := upMarkerRe.FindStringIndex(content)
+	downIdx := downMarkerRe.FindStringIndex(content)
+
+	switch {
+	case upIdx != nil && downIdx != nil:
+		if upIdx[0] < downIdx[0] {
+			up = content[upIdx[1]:downIdx[0]]
+			down = content[downIdx[1]:]
+		} else {
+			down = content[downIdx[1]:upIdx[0]]
+			up = content[upIdx[1]:]
+		}
+	case upIdx != nil:
+		up = content[upIdx[1]:]
+	case downIdx != nil:
+		down = content[downIdx[1]:]
+	}
+	return up, down
+}
+
+// DirectionLineOffsets reports how many lines of content precede the up and
+// down sections of a single-file migration (i.e. the number of newlines before
+// the end of the "-- migrate:up" / "-- +goose Up" marker, and likewise for
+// down; 0 for a marker on the first line). A caller that
+// Parses each section's string independently can add the relevant offset to
+// a Statement's Line to recover its line number in the original file. A side
+// with no marker present reports offset 0 for it.
+func DirectionLineOffsets(content string) (upOffset, downOffset int) {
+	if idx := upMarkerRe.FindStringIndex(content); idx != nil {
+		upOffset = strings.Count(content[:idx[1]], "\n")
+	}
+	if idx := downMarkerRe.FindStringIndex(content); idx != nil {
+		downOffset = strings.Count(content[:idx[1]], "\n")
+	}
+	return upOffset, downOffset
+}
+
+// AnalyzePair checks an up migration's statements against its paired down
+// migration's statements (downSQL may be empty when no down file/section
+// exists) and returns every finding.
+func AnalyzePair(upSQL, downSQL string, opts Options) []Finding {
+	upStmts := Parse(upSQL)
+	downStmts := Parse(downSQL)
+
+	var findings []Finding
+
+	for _, up := range upStmts {
+		switch up.Verb {
+		case "CREATE_TABLE":
+			if !hasDropTable(downStmts, up.Table) {
+				findings = append(findings, Finding{
+					Side: "up", StmtIndex: up.Index, Line: up.Line,
+					Message: fmt.Sprintf("CREATE TABLE %s has no corresponding DROP TABLE in the down migration", up.Table),
+				})
+			}
+		case "ALTER_ADD_COLUMN":
+			if !hasDropColumn(downStmts, up.Table, up.Column) {
+				findings = append(findings, Finding{
+					Side: "up", StmtIndex: up.Index, Line: up.Line,
+					Message: fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s has no corresponding DROP COLUMN in the down migration", up.Table, up.Column),
+				})
+			}
+		}
+		if opts.Dialect == "postgres" && serialRe.MatchString(up.Text) {
+			findings = append(findings, Finding{
+				Side: "up", StmtIndex: up.Index, Line: up.Line,
+				Message: "use GENERATED ... AS IDENTITY instead of SERIAL/AUTO_INCREMENT on postgres",
+			})
+		}
+	}
+
+	for _, down := range downStmts {
+		switch down.Verb {
+		case "DROP_DATABASE":
+			findings = append(findings, Finding{
+				Side: "down", StmtIndex: down.Index, Line: down.Line,
+				Message: "DROP DATABASE in a down migration is irreversible and destructive",
+			})
+		case "TRUNCATE":
+			findings = append(findings, Finding{
+				Side: "down", StmtIndex: down.Index, Line: down.Line,
+				Message: fmt.Sprintf("TRUNCATE %s in a down migration is irreversible and destructive", down.Table),
+			})
+		}
+	}
+
+	if opts.RequireTx && len(upStmts) > 0 && !txBeginRe.MatchString(upSQL) {
+		findings = append(findings, Finding{
+			Side: "up", StmtIndex: 0, Line: upStmts[0].Line,
+			Message: "up migration is missing a transaction wrapper (BEGIN ... COMMIT)",
+		})
+	}
+
+	return findings
+}
+
+func hasDropTable(stmts []Statement, table string) bool {
+	for _, s := range stmts {
+		if s.Verb == "DROP_TABLE" && strings.EqualFold(s.Table, table) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasDropColumn(stmts []Statement, table, column string) bool {
+	for _, s := range stmts {
+		if s.Verb == "ALTER_DROP_COLUMN" && strings.EqualFold(s.Table, table) && strings.EqualFold(s.Column, column) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package sqlcheck
+
+import "testing"
+
+func TestSplitRespectsDollarQuotedBlocks(t *testing.T) {
+	sql := `CREATE FUNCTION f() RETURNS void AS $$
+BEGIN
+  INSERT INTO logs VALUES (1);
+END;
+$$ LANGUAGE plpgsql;
+DROP TABLE logs;`
+
+	stmts := Split(sql)
+	if len(stmts) != 2 {
+		t.Fatalf("expected 2 statements, got %d: %v", len(stmts), stmts)
+	}
+}
+
+func TestSplitSkipsLineComments(t *testing.T) {
+	sql := "-- a comment with a ; in it\nCREATE TABLE users (id int);\n-- another\nDROP TABLE users;"
+
+	stmts := Split(sql)
+	if len(stmts) != 2 {
+		t.Fatalf("expected 2 statements, got %d: %v", len(stmts), stmts)
+	}
+}
+
+func TestAnalyzePairFindsMissingDropTable(t *testing.T) {
+	findings := AnalyzePair("CREATE TABLE users (id int);", "", Options{})
+	if len(findings) != 1 || findings[0].Message == "" {
+		t.Fatalf("expected one missing-drop finding, got %v", findings)
+	}
+	if findings[0].Side != "up" {
+		t.Fatalf("expected finding on the up side, got %q", findings[0].Side)
+	}
+}
+
+func TestAnalyzePairPassesWhenDropTablePresent(t *testing.T) {
+	findings := AnalyzePair("CREATE TABLE users (id int);", "DROP TABLE users;", Options{})
+	if len(findings) != 0 {
+		t.Fatalf("expected no findings, got %v", findings)
+	}
+}
+
+func TestAnalyzePairFindsMissingDropColumn(t *testing.T) {
+	findings := AnalyzePair("ALTER TABLE users ADD COLUMN age int;", "", Options{})
+	if len(findings) != 1 {
+		t.Fatalf("expected one finding, got %v", findings)
+	}
+}
+
+func TestAnalyzePairFlagsDropDatabaseInDown(t *testing.T) {
+	findings := AnalyzePair("", "DROP DATABASE app;", Options{})
+	if len(findings) != 1 || findings[0].Side != "down" {
+		t.Fatalf("expected one down-side finding, got %v", findings)
+	}
+}
+
+func TestAnalyzePairFlagsTruncateInDown(t *testing.T) {
+	findings := AnalyzePair("", "TRUNCATE users;", Options{})
+	if len(findings) != 1 || findings[0].Side != "down" {
+		t.Fatalf("expected one down-side finding, got %v", findings)
+	}
+}
+
+func TestAnalyzePairRequireTxFlagsMissingBegin(t *testing.T) {
+	findings := AnalyzePair("CREATE TABLE users (id int);", "DROP TABLE users;", Options{RequireTx: true})
+	found := false
+	for _, f := range findings {
+		if f.StmtIndex == 0 && f.Side == "up" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a missing-transaction finding, got %v", findings)
+	}
+}
+
+func TestAnalyzePairRequireTxPassesWithBegin(t *testing.T) {
+	findings := AnalyzePair("BEGIN;\nCREATE TABLE users (id int);\nCOMMIT;", "DROP TABLE users;", Options{RequireTx: true})
+	for _, f := range findings {
+		if f.Message == "up migration is missing a transaction wrapper (BEGIN ... COMMIT)" {
+			t.Fatalf("did not expect a missing-transaction finding, got %v", findings)
+		}
+	}
+}
+
+func TestAnalyzePairPostgresFlagsSerial(t *testing.T) {
+	findings := AnalyzePair("CREATE TABLE users (id SERIAL);", "DROP TABLE users;", Options{Dialect: "postgres"})
+	found := false
+	for _, f := range findings {
+		if f.Message == "use GENERATED ... AS IDENTITY instead of SERIAL/AUTO_INCREMENT on postgres" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a SERIAL advisory finding, got %v", findings)
+	}
+}
+
+func TestAnalyzePairSetsLine(t *testing.T) {
+	findings := AnalyzePair("CREATE TABLE users (id int);", "", Options{})
+	if len(findings) != 1 || findings[0].Line != 1 {
+		t.Fatalf("expected a finding on line 1, got %v", findings)
+	}
+
+	findings = AnalyzePair("\n\nCREATE TABLE users (id int);", "", Options{})
+	if len(findings) != 1 || findings[0].Line != 3 {
+		t.Fatalf("expected a finding on line 3, got %v", findings)
+	}
+}
+
+func TestAnalyzePairSetsLineWithInlineTrailingComment(t *testing.T) {
+	sql := "CREATE TABLE foo (\n  id int, -- comment\n  name text\n);"
+	findings := AnalyzePair(sql, "", Options{})
+	if len(findings) != 1 || findings[0].Line != 1 {
+		t.Fatalf("expected a finding on line 1 despite the inline comment, got %v", findings)
+	}
+}
+
+func TestDirectionLineOffsets(t *testing.T) {
+	content := "-- migrate:up\nCREATE TABLE users (id int);\n-- migrate:down\nDROP TABLE users;\n"
+	upOffset, downOffset := DirectionLineOffsets(content)
+	up, down := SplitDirections(content)
+	upLine := Parse(up)[0].Line + upOffset
+	downLine := Parse(down)[0].Line + downOffset
+	if upLine != 2 {
+		t.Fatalf("expected CREATE TABLE to resolve to absolute line 2, got %d (offset=%d)", upLine, upOffset)
+	}
+	if downLine != 4 {
+		t.Fatalf("expected DROP TABLE to resolve to absolute line 4, got %d (offset=%d)", downLine, downOffset)
+	}
+}
+
+func TestSplitDirectionsDbmateMarkers(t *testing.T) {
+	content := "-- migrate:up\nCREATE TABLE users (id int);\n-- migrate:down\nDROP TABLE users;\n"
+	up, down := SplitDirections(content)
+	if Split(up)[0] != "CREATE TABLE users (id int)" {
+		t.Fatalf("unexpected up section: %q", up)
+	}
+	if Split(down)[0] != "DROP TABLE users" {
+		t.Fatalf("unexpected down section: %q", down)
+	}
+}
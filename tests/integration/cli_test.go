@@ -61,6 +61,62 @@ func TestCLI_ExtLeadingDotAccepted(t *testing.T) {
 	}
 }
 
+func TestCLI_JSONFormatReportsFindings(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, "000001_create.up.sql", "")
+
+	stdout, stderr, code := runCli(t, "-path", dir, "-require-down", "-format", "json")
+	if code != 1 {
+		t.Fatalf("expected exit 1, got %d (stdout=%q, stderr=%q)", code, stdout, stderr)
+	}
+	if !strings.Contains(stdout, `"rule": "missing-down"`) {
+		t.Fatalf("expected missing-down rule in JSON output, got stdout=%q", stdout)
+	}
+}
+
+func TestCLI_UnknownConventionRejected(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, "V1__create.sql", "")
+
+	stdout, stderr, code := runCli(t, "-path", dir, "-convention", "flywy")
+	if code == 0 {
+		t.Fatalf("expected non-zero exit for an unrecognized -convention, got 0 (stdout=%q)", stdout)
+	}
+	if !strings.Contains(stderr, "-convention") {
+		t.Fatalf("expected a -convention error, got stderr=%q", stderr)
+	}
+}
+
+func TestCLI_PathFlagRejectedWithProfiles(t *testing.T) {
+	dir := t.TempDir()
+	serviceA := filepath.Join(dir, "service_a")
+	serviceB := filepath.Join(dir, "service_b")
+	if err := os.MkdirAll(serviceA, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.MkdirAll(serviceB, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	write(t, serviceA, "000001_create.up.sql", "")
+	write(t, serviceA, "000001_create.down.sql", "")
+	write(t, serviceB, "000001_create.up.sql", "")
+	write(t, serviceB, "000001_create.down.sql", "")
+
+	configPath := filepath.Join(dir, ".miglint.toml")
+	config := "[[profiles]]\nname = \"service_a\"\npath = \"" + serviceA + "\"\n\n[[profiles]]\nname = \"service_b\"\npath = \"" + serviceB + "\"\n"
+	if err := os.WriteFile(configPath, []byte(config), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	stdout, stderr, code := runCli(t, "-config", configPath, "-path", serviceA)
+	if code == 0 {
+		t.Fatalf("expected non-zero exit when -path is combined with [[profiles]], got 0 (stdout=%q)", stdout)
+	}
+	if !strings.Contains(stderr, "-path") {
+		t.Fatalf("expected an error mentioning -path, got stderr=%q", stderr)
+	}
+}
+
 func runCli(t *testing.T, args ...string) (stdout, stderr string, code int) {
 	t.Helper()
 	cmd := exec.Command("go", append([]string{"run", "./cmd/miglint"}, args...)...)